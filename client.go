@@ -2,80 +2,363 @@ package openrgb
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
+	"io"
+	"log"
 	"net"
+	"sync"
+	"time"
 )
 
-// Client is a TCP client that connects to the OpenRGB Server.
+const defaultClientName = "GoClient"
+
+// pendingKey identifies an in-flight request awaiting a response. The OpenRGB SDK
+// echoes the request's command and device IDs back on its response packet, so that
+// pair is sufficient to route a reply to a caller waiting on it. Multiple callers
+// can share a pendingKey (e.g. two concurrent GetControllerCount calls), so each
+// key queues its waiters FIFO and they're matched to responses in request order.
+type pendingKey struct {
+	commandID int
+	deviceID  int
+}
+
+type pendingResponse struct {
+	data []byte
+	err  error
+}
+
+// Client is a TCP client that connects to the OpenRGB Server. A Client owns a
+// background goroutine that reads and frames all incoming packets, so it is
+// safe to call its methods concurrently from multiple goroutines.
 type Client struct {
-	clientSock net.Conn
+	conn net.Conn
+
+	writeMu      sync.Mutex
+	writeTimeout time.Duration
+	readTimeout  time.Duration
+	logger       *log.Logger
+
+	mu      sync.Mutex
+	pending map[pendingKey][]chan pendingResponse
+	closed  chan struct{}
+	closeWg sync.Once
+
+	// subMu guards deviceListSubscribers, which backs OnDeviceListUpdated.
+	subMu                 sync.Mutex
+	nextSubID             int
+	deviceListSubscribers map[int]func()
+
+	// protocolVersion is the negotiated SDK protocol version, i.e. the lower
+	// of the version this client implements and the version the server supports.
+	protocolVersion int
+}
+
+// ClientOption configures a Client created via ConnectWithOptions.
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	clientName   string
+	dialTimeout  time.Duration
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+	logger       *log.Logger
+}
+
+// WithClientName sets the name this client reports to the OpenRGB server.
+// Defaults to "GoClient".
+func WithClientName(name string) ClientOption {
+	return func(cfg *clientConfig) { cfg.clientName = name }
 }
 
-// Close the underlying TCP socket.
+// WithDialTimeout bounds how long ConnectWithOptions waits for the initial TCP dial.
+func WithDialTimeout(d time.Duration) ClientOption {
+	return func(cfg *clientConfig) { cfg.dialTimeout = d }
+}
+
+// WithReadTimeout bounds how long the background reader waits for the server to
+// send the next packet before the connection is considered dead.
+func WithReadTimeout(d time.Duration) ClientOption {
+	return func(cfg *clientConfig) { cfg.readTimeout = d }
+}
+
+// WithWriteTimeout bounds how long a single write to the server may take.
+func WithWriteTimeout(d time.Duration) ClientOption {
+	return func(cfg *clientConfig) { cfg.writeTimeout = d }
+}
+
+// WithLogger gives the Client a logger to report dropped or unexpected packets to.
+// When omitted, the Client logs nothing.
+func WithLogger(l *log.Logger) ClientOption {
+	return func(cfg *clientConfig) { cfg.logger = l }
+}
+
+// Close the underlying TCP socket and stop the background reader.
 func (c *Client) Close() error {
-	return c.clientSock.Close()
+	err := c.conn.Close()
+	c.closeWg.Do(func() { close(c.closed) })
+	return err
 }
 
 // Connect takes in the host and port of the OpenRGB server and creates a TCP socket.
 // Returns an instance of `*openrgb.Client` or an error.
 func Connect(host string, port int) (*Client, error) {
+	return ConnectWithOptions(host, port)
+}
+
+// ConnectWithOptions is like Connect but accepts ClientOptions to configure the
+// client name, timeouts, and logging.
+func ConnectWithOptions(host string, port int, opts ...ClientOption) (*Client, error) {
+	cfg := clientConfig{clientName: defaultClientName}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	addr := fmt.Sprintf("%s:%d", host, port)
-	sock, err := net.Dial("tcp", addr)
+	dialer := net.Dialer{Timeout: cfg.dialTimeout}
+	sock, err := dialer.Dial("tcp", addr)
 	if err != nil {
 		return nil, err
 	}
 
-	c := &Client{clientSock: sock}
+	c := newClient(sock, cfg)
 
-	err = c.sendMessage(commandSetClientName, 0, bytes.NewBufferString("GoClient"))
-	if err != nil {
+	if err := c.sendMessage(commandSetClientName, 0, bytes.NewBufferString(cfg.clientName)); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	if err := c.negotiateProtocolVersion(context.Background()); err != nil {
+		c.Close()
 		return nil, err
 	}
 
 	return c, nil
 }
 
+func newClient(conn net.Conn, cfg clientConfig) *Client {
+	c := &Client{
+		conn:                  conn,
+		writeTimeout:          cfg.writeTimeout,
+		readTimeout:           cfg.readTimeout,
+		logger:                cfg.logger,
+		pending:               make(map[pendingKey][]chan pendingResponse),
+		closed:                make(chan struct{}),
+		deviceListSubscribers: make(map[int]func()),
+	}
+
+	go c.readLoop()
+
+	return c
+}
+
+// readLoop continuously reads framed packets off the connection and routes each
+// one to the goroutine waiting on it, for as long as the connection is alive.
+// Any unsolicited NET_PACKET_ID_DEVICE_LIST_UPDATED packet is fanned out to the
+// subscribers registered via OnDeviceListUpdated; anything else unexpected is
+// logged and dropped.
+func (c *Client) readLoop() {
+	for {
+		header, payload, err := c.readFrame()
+		if err != nil {
+			c.failPending(err)
+			return
+		}
+
+		key := pendingKey{commandID: int(header.commandID), deviceID: int(header.deviceID)}
+
+		c.mu.Lock()
+		var ch chan pendingResponse
+		if queue := c.pending[key]; len(queue) > 0 {
+			ch = queue[0]
+			if len(queue) == 1 {
+				delete(c.pending, key)
+			} else {
+				c.pending[key] = queue[1:]
+			}
+		}
+		c.mu.Unlock()
+
+		switch {
+		case ch != nil:
+			ch <- pendingResponse{data: payload}
+		case int(header.commandID) == commandDeviceListUpdated:
+			c.dispatchDeviceListUpdated()
+		default:
+			if c.logger != nil {
+				c.logger.Printf("openrgb: dropped unexpected message (command=%d device=%d)", header.commandID, header.deviceID)
+			}
+		}
+	}
+}
+
+// failPending unblocks every in-flight call with err, called once the connection
+// is no longer usable so callers don't hang forever waiting on a dead socket.
+func (c *Client) failPending(err error) {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[pendingKey][]chan pendingResponse)
+	c.mu.Unlock()
+
+	for _, queue := range pending {
+		for _, ch := range queue {
+			ch <- pendingResponse{err: err}
+		}
+	}
+
+	c.closeWg.Do(func() { close(c.closed) })
+}
+
+func (c *Client) readFrame() (orgbHeader, []byte, error) {
+	if c.readTimeout > 0 {
+		_ = c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+	}
+
+	buf := make([]byte, 16)
+	if _, err := io.ReadFull(c.conn, buf); err != nil {
+		return orgbHeader{}, nil, err
+	}
+
+	header := decodeHeader(buf)
+
+	payload := make([]byte, header.length)
+	if header.length > 0 {
+		if _, err := io.ReadFull(c.conn, payload); err != nil {
+			return orgbHeader{}, nil, err
+		}
+	}
+
+	return header, payload, nil
+}
+
+// request enqueues a waiter for the response to (command, deviceID), sends the
+// request, and blocks until the response arrives, ctx is done, or the Client
+// closes. Callers sharing a (command, deviceID) pair are queued FIFO and
+// matched to responses in request order, since the server echoes no finer-
+// grained correlation ID than that pair.
+func (c *Client) request(ctx context.Context, command, deviceID int, buffer *bytes.Buffer) ([]byte, error) {
+	key := pendingKey{commandID: command, deviceID: deviceID}
+	ch := make(chan pendingResponse, 1)
+
+	c.mu.Lock()
+	c.pending[key] = append(c.pending[key], ch)
+	c.mu.Unlock()
+
+	if err := c.sendMessageContext(ctx, command, deviceID, buffer); err != nil {
+		c.removePending(key, ch)
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		return resp.data, resp.err
+	case <-ctx.Done():
+		c.removePending(key, ch)
+		return nil, ctx.Err()
+	case <-c.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// removePending removes ch from key's waiter queue without disturbing other
+// waiters sharing the same key, e.g. after a send fails or ctx is canceled
+// before a response arrives.
+func (c *Client) removePending(key pendingKey, ch chan pendingResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	queue := c.pending[key]
+	for i, waiter := range queue {
+		if waiter == ch {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+
+	if len(queue) == 0 {
+		delete(c.pending, key)
+	} else {
+		c.pending[key] = queue
+	}
+}
+
+// negotiateProtocolVersion asks the server which SDK protocol version it speaks and
+// stores the lower of the server's version and currentProtocolVersion on the Client.
+// Every wire-format decision that varies by protocol version reads c.protocolVersion.
+func (c *Client) negotiateProtocolVersion(ctx context.Context) error {
+	message, err := c.request(ctx, commandRequestProtocolVersion, 0, uint32Buffer(currentProtocolVersion))
+	if err != nil {
+		return err
+	}
+
+	serverVersion := int(binary.LittleEndian.Uint32(message))
+	c.protocolVersion = currentProtocolVersion
+	if serverVersion < c.protocolVersion {
+		c.protocolVersion = serverVersion
+	}
+
+	return nil
+}
+
+// requireProtocolVersion fails clearly if command needs a newer SDK protocol
+// version than was negotiated with the server, rather than silently sending a
+// command or wire format an older OpenRGB server won't understand.
+func (c *Client) requireProtocolVersion(command int) error {
+	if min, ok := minProtocolVersion[command]; ok && c.protocolVersion < min {
+		return fmt.Errorf("openrgb: command %d requires protocol version %d, server negotiated %d", command, min, c.protocolVersion)
+	}
+	return nil
+}
+
+// uint32Buffer wraps a little-endian uint32 payload in a *bytes.Buffer for sendMessage.
+func uint32Buffer(v uint32) *bytes.Buffer {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, v)
+	return bytes.NewBuffer(buf)
+}
+
 // GetGetControllerCount returns the total number of devices detected by OpenRGB.
 // The controller count starts from 0, which means, for `n` number of controllers,
 // the count will be `n-1`.
 func (c *Client) GetControllerCount() (int, error) {
-	err := c.sendMessage(commandRequestControllerCount, 0, nil)
-	if err != nil {
-		return 0, err
-	}
+	return c.GetControllerCountContext(context.Background())
+}
 
-	message, err := c.readMessage()
+// GetControllerCountContext is GetControllerCount with caller-supplied cancellation.
+func (c *Client) GetControllerCountContext(ctx context.Context) (int, error) {
+	message, err := c.request(ctx, commandRequestControllerCount, 0, nil)
 	if err != nil {
 		return 0, err
 	}
-	count := int(binary.LittleEndian.Uint32(message))
 
-	return count, nil
+	return int(binary.LittleEndian.Uint32(message)), nil
 }
 
 // GetDeviceController queries the OpenRGB server for a device and returns its `openrgb.Device`
 // representation. The `deviceID` parameter is an index that starts from 0.
 func (c *Client) GetDeviceController(deviceID int) (Device, error) {
-	if err := c.sendMessage(commandRequestControllerData, deviceID, nil); err != nil {
-		return Device{}, err
-	}
-	message, err := c.readMessage()
-	if err != nil {
-		return Device{}, err
-	}
+	return c.GetDeviceControllerContext(context.Background(), deviceID)
+}
 
-	d, err := readDevice(message)
+// GetDeviceControllerContext is GetDeviceController with caller-supplied cancellation.
+func (c *Client) GetDeviceControllerContext(ctx context.Context, deviceID int) (Device, error) {
+	message, err := c.request(ctx, commandRequestControllerData, deviceID, nil)
 	if err != nil {
 		return Device{}, err
 	}
 
-	return d, nil
+	return readDevice(message)
 }
 
 // UpdateLEDs updates multiple LEDs on device-level. Length of the `colors` parameter
 // MUST match the length of `openrgb.Device.Colors`.
 func (c *Client) UpdateLEDs(deviceID int, colors []Color) error {
+	return c.UpdateLEDsContext(context.Background(), deviceID, colors)
+}
+
+// UpdateLEDsContext is UpdateLEDs with caller-supplied cancellation.
+func (c *Client) UpdateLEDsContext(ctx context.Context, deviceID int, colors []Color) error {
 	lenColors := len(colors)
 	size := 2 + (4 * lenColors)
 
@@ -98,12 +381,17 @@ func (c *Client) UpdateLEDs(deviceID int, colors []Color) error {
 		return err
 	}
 
-	return c.sendMessage(commandUpdateLEDs, deviceID, cmd)
+	return c.sendMessageContext(ctx, commandUpdateLEDs, deviceID, cmd)
 }
 
 // UpdateZoneLEDs updates multiple LEDs on zone-level. Length of the `colors` parameter
 // MUST match the length of `Colors` parameter in `openrgb.Zone`
 func (c *Client) UpdateZoneLEDs(deviceID, zoneID int, colors []Color) error {
+	return c.UpdateZoneLEDsContext(context.Background(), deviceID, zoneID, colors)
+}
+
+// UpdateZoneLEDsContext is UpdateZoneLEDs with caller-supplied cancellation.
+func (c *Client) UpdateZoneLEDsContext(ctx context.Context, deviceID, zoneID int, colors []Color) error {
 	lenColors := len(colors)
 	size := 6 + (4 * lenColors)
 
@@ -127,10 +415,137 @@ func (c *Client) UpdateZoneLEDs(deviceID, zoneID int, colors []Color) error {
 		return err
 	}
 
-	return c.sendMessage(commandUpdateZoneLEDs, deviceID, cmd)
+	return c.sendMessageContext(ctx, commandUpdateZoneLEDs, deviceID, cmd)
 }
 
+// UpdateSingleLED updates a single LED on device-level. The `ledID` parameter is
+// an index into `openrgb.Device.Colors`/`openrgb.Device.LEDs`.
+func (c *Client) UpdateSingleLED(deviceID, ledID int, color Color) error {
+	return c.UpdateSingleLEDContext(context.Background(), deviceID, ledID, color)
+}
+
+// UpdateSingleLEDContext is UpdateSingleLED with caller-supplied cancellation.
+func (c *Client) UpdateSingleLEDContext(ctx context.Context, deviceID, ledID int, color Color) error {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint32(buf, uint32(ledID))
+	buf[4], buf[5], buf[6] = color.Red, color.Green, color.Blue
+
+	return c.sendMessageContext(ctx, commandUpdateSingleLED, deviceID, bytes.NewBuffer(buf))
+}
+
+// SetCustomMode switches a device into its "custom" mode, the mode OpenRGB
+// puts a device into before applying direct LED updates.
+func (c *Client) SetCustomMode(deviceID int) error {
+	return c.sendMessageContext(context.Background(), commandSetCustomMode, deviceID, nil)
+}
+
+// UpdateMode applies `mode` to the mode at index `modeID` on a device without
+// persisting it, mirroring the OpenRGB SDK's live mode preview behavior.
+func (c *Client) UpdateMode(deviceID, modeID int, mode Mode) error {
+	if err := c.requireProtocolVersion(commandUpdateMode); err != nil {
+		return err
+	}
+	return c.sendMessageContext(context.Background(), commandUpdateMode, deviceID, bytes.NewBuffer(encodeMode(modeID, mode)))
+}
+
+// SaveMode persists `mode` at index `modeID` on a device, so it survives a
+// server or device restart.
+func (c *Client) SaveMode(deviceID, modeID int, mode Mode) error {
+	if err := c.requireProtocolVersion(commandSaveMode); err != nil {
+		return err
+	}
+	return c.sendMessageContext(context.Background(), commandSaveMode, deviceID, bytes.NewBuffer(encodeMode(modeID, mode)))
+}
+
+// ResizeZone changes the LED count of a resizable zone, e.g. an addressable strip.
+func (c *Client) ResizeZone(deviceID, zoneID, newSize int) error {
+	if err := c.requireProtocolVersion(commandResizeZone); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint32(buf[0:], uint32(zoneID))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(newSize))
+
+	return c.sendMessageContext(context.Background(), commandResizeZone, deviceID, bytes.NewBuffer(buf))
+}
+
+// GetProfileList returns the names of the profiles saved on the OpenRGB server.
+func (c *Client) GetProfileList() ([]string, error) {
+	return c.GetProfileListContext(context.Background())
+}
+
+// GetProfileListContext is GetProfileList with caller-supplied cancellation.
+func (c *Client) GetProfileListContext(ctx context.Context) ([]string, error) {
+	if err := c.requireProtocolVersion(commandRequestProfileList); err != nil {
+		return nil, err
+	}
+
+	message, err := c.request(ctx, commandRequestProfileList, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &deviceReader{buf: message}
+	_ = r.uint32() // data size
+	count := r.uint16()
+	names := make([]string, count)
+	for i := range names {
+		names[i] = r.string()
+	}
+
+	return names, nil
+}
+
+// LoadProfile tells the OpenRGB server to load the profile named `name`.
+func (c *Client) LoadProfile(name string) error {
+	if err := c.requireProtocolVersion(commandRequestLoadProfile); err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	writeString(buf, name)
+	return c.sendMessageContext(context.Background(), commandRequestLoadProfile, 0, buf)
+}
+
+// SaveProfile tells the OpenRGB server to save its current state to the profile named `name`.
+func (c *Client) SaveProfile(name string) error {
+	if err := c.requireProtocolVersion(commandRequestSaveProfile); err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	writeString(buf, name)
+	return c.sendMessageContext(context.Background(), commandRequestSaveProfile, 0, buf)
+}
+
+// DeleteProfile tells the OpenRGB server to delete the profile named `name`.
+func (c *Client) DeleteProfile(name string) error {
+	if err := c.requireProtocolVersion(commandRequestDeleteProfile); err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	writeString(buf, name)
+	return c.sendMessageContext(context.Background(), commandRequestDeleteProfile, 0, buf)
+}
+
+// sendMessage is sendMessageContext with context.Background().
 func (c *Client) sendMessage(command, deviceID int, buffer *bytes.Buffer) error {
+	return c.sendMessageContext(context.Background(), command, deviceID, buffer)
+}
+
+// sendMessageContext frames command/deviceID/buffer and writes it to the
+// connection under writeMu, applying ctx's deadline (falling back to the
+// configured write timeout) to that single write. The deadline is set and
+// the write performed in the same critical section so a canceled or slow
+// caller's write is bounded by its own deadline instead of a detached
+// goroutine holding writeMu indefinitely and head-of-line-blocking every
+// other concurrent caller.
+func (c *Client) sendMessageContext(ctx context.Context, command, deviceID int, buffer *bytes.Buffer) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
 	bufLen := 0
 	if buffer != nil {
 		bufLen = buffer.Len()
@@ -146,21 +561,19 @@ func (c *Client) sendMessage(command, deviceID int, buffer *bytes.Buffer) error
 		header.Write(buffer.Bytes())
 	}
 
-	_, err := c.clientSock.Write(header.Bytes())
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
 
-	return err
-}
-
-func (c *Client) readMessage() ([]byte, error) {
-	buf := make([]byte, 16)
-	_, err := c.clientSock.Read(buf)
-	if err != nil {
-		return nil, err
+	deadline := time.Time{}
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	} else if c.writeTimeout > 0 {
+		deadline = time.Now().Add(c.writeTimeout)
 	}
+	// A zero deadline clears any deadline left on the conn by an earlier call.
+	_ = c.conn.SetWriteDeadline(deadline)
 
-	header := decodeHeader(buf)
-	buf = make([]byte, header.length)
-	_, err = c.clientSock.Read(buf)
+	_, err := c.conn.Write(header.Bytes())
 
-	return buf, err
+	return err
 }
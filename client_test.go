@@ -0,0 +1,142 @@
+package openrgb
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestClientConcurrentRequestsSameKey exercises two concurrent calls that
+// share a pendingKey (GetControllerCount always keys on
+// {commandRequestControllerCount, 0}). Both must receive a response, matched
+// in the order the fake server answers them; neither should be clobbered or
+// left hanging.
+func TestClientConcurrentRequestsSameKey(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	c := newClient(clientConn, clientConfig{})
+	defer c.Close()
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			header, _, err := readRawFrame(serverConn)
+			if err != nil {
+				return
+			}
+			if err := writeRawFrame(serverConn, header.commandID, header.deviceID, le32(uint32(i+1))); err != nil {
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	results := make(chan int, 2)
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			n, err := c.GetControllerCountContext(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			results <- n
+		}()
+	}
+
+	got := map[int]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case n := <-results:
+			got[n] = true
+		case err := <-errs:
+			t.Fatalf("GetControllerCountContext: %v", err)
+		case <-ctx.Done():
+			t.Fatal("timed out: a concurrent request sharing a pendingKey was never delivered a response")
+		}
+	}
+
+	if !got[1] || !got[2] {
+		t.Fatalf("expected responses {1, 2} to both be delivered, got %v", got)
+	}
+}
+
+// TestClientPendingRequestsFailOnDisconnect confirms that when the background
+// reader observes the connection die, any request still waiting on a response
+// is unblocked with an error instead of hanging forever.
+func TestClientPendingRequestsFailOnDisconnect(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+
+	c := newClient(clientConn, clientConfig{})
+	defer c.Close()
+
+	go func() {
+		if _, _, err := readRawFrame(serverConn); err != nil {
+			return
+		}
+		serverConn.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := c.GetControllerCountContext(ctx); err == nil {
+		t.Fatal("expected an error once the server closed the connection, got nil")
+	}
+}
+
+// TestClientSendMessageContextHonorsItsOwnDeadline confirms a call's own ctx
+// deadline governs its write (not the Client's static write timeout, and not
+// a detached goroutine nobody is waiting on anymore), and that a subsequent,
+// healthy call isn't head-of-line-blocked behind it.
+func TestClientSendMessageContextHonorsItsOwnDeadline(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	c := newClient(clientConn, clientConfig{writeTimeout: 2 * time.Second})
+	defer c.Close()
+
+	// Nobody ever reads this frame, so the write can only return once its own
+	// short context deadline trips it.
+	shortCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetControllerCountContext(shortCtx)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected the short-deadline call to fail, got nil")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("call with a 20ms context deadline took %v: its write deadline was clobbered by the client's static write timeout", elapsed)
+	}
+
+	go func() {
+		header, _, err := readRawFrame(serverConn)
+		if err != nil {
+			return
+		}
+		_ = writeRawFrame(serverConn, header.commandID, header.deviceID, le32(7))
+	}()
+
+	longCtx, cancel2 := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel2()
+
+	start = time.Now()
+	n, err := c.GetControllerCountContext(longCtx)
+	elapsed = time.Since(start)
+
+	if err != nil {
+		t.Fatalf("GetControllerCountContext: %v", err)
+	}
+	if n != 7 {
+		t.Fatalf("got %d, want 7", n)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("second call took %v: it was head-of-line-blocked behind the first call's write", elapsed)
+	}
+}
@@ -0,0 +1,247 @@
+package openrgb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Color is an RGB color value as understood by the OpenRGB SDK.
+type Color struct {
+	Red, Green, Blue uint8
+}
+
+// LED describes a single controllable LED on a device.
+type LED struct {
+	Name  string
+	Value int
+}
+
+// Zone is a logical grouping of LEDs on a device, e.g. "Fan 1" or "Strip".
+// MatrixMap, MatrixHeight and MatrixWidth are only populated for MATRIX-type
+// zones (e.g. a keyboard); MatrixMap is row-major and holds the LED index at
+// each position, or -1 where a position has no LED.
+type Zone struct {
+	Name         string
+	Type         int
+	LEDsMin      int
+	LEDsMax      int
+	LEDsCount    int
+	MatrixLength int
+	MatrixHeight int
+	MatrixWidth  int
+	MatrixMap    []int
+	Colors       []Color
+}
+
+// Mode describes one of a device's selectable lighting modes (Static, Breathing, ...).
+type Mode struct {
+	Name      string
+	Value     int
+	Flags     int
+	SpeedMin  int
+	SpeedMax  int
+	ColorMin  int
+	ColorMax  int
+	Speed     int
+	Direction int
+	ColorMode int
+	Colors    []Color
+}
+
+// Device is the full description of a single RGB-capable controller.
+type Device struct {
+	Type        int
+	Name        string
+	Description string
+	Version     string
+	Serial      string
+	Location    string
+	ActiveMode  int
+	Modes       []Mode
+	Zones       []Zone
+	LEDs        []LED
+	Colors      []Color
+}
+
+// deviceReader is a cursor over a single controller-data response payload.
+type deviceReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *deviceReader) uint16() int {
+	v := binary.LittleEndian.Uint16(r.buf[r.pos:])
+	r.pos += 2
+	return int(v)
+}
+
+func (r *deviceReader) uint32() int {
+	v := binary.LittleEndian.Uint32(r.buf[r.pos:])
+	r.pos += 4
+	return int(v)
+}
+
+func (r *deviceReader) string() string {
+	n := r.uint16()
+	if n == 0 {
+		return ""
+	}
+	s := string(r.buf[r.pos : r.pos+n-1])
+	r.pos += n
+	return s
+}
+
+func (r *deviceReader) color() Color {
+	c := Color{Red: r.buf[r.pos], Green: r.buf[r.pos+1], Blue: r.buf[r.pos+2]}
+	r.pos += 4
+	return c
+}
+
+func (r *deviceReader) colors() []Color {
+	count := r.uint16()
+	colors := make([]Color, count)
+	for i := range colors {
+		colors[i] = r.color()
+	}
+	return colors
+}
+
+// readDevice decodes a NET_PACKET_ID_REQUEST_CONTROLLER_DATA response body into a Device.
+func readDevice(data []byte) (Device, error) {
+	if len(data) < 4 {
+		return Device{}, fmt.Errorf("openrgb: controller data too short: %d bytes", len(data))
+	}
+
+	r := &deviceReader{buf: data}
+	_ = r.uint32() // data size, already known from the packet header
+
+	d := Device{}
+	d.Type = r.uint32()
+	d.Name = r.string()
+	d.Description = r.string()
+	d.Version = r.string()
+	d.Serial = r.string()
+	d.Location = r.string()
+
+	numModes := r.uint16()
+	d.ActiveMode = r.uint32()
+	d.Modes = make([]Mode, numModes)
+	for i := range d.Modes {
+		d.Modes[i] = readMode(r)
+	}
+
+	numZones := r.uint16()
+	d.Zones = make([]Zone, numZones)
+	for i := range d.Zones {
+		d.Zones[i] = readZone(r)
+	}
+
+	numLEDs := r.uint16()
+	d.LEDs = make([]LED, numLEDs)
+	for i := range d.LEDs {
+		d.LEDs[i] = LED{Name: r.string(), Value: r.uint32()}
+	}
+
+	d.Colors = r.colors()
+
+	return d, nil
+}
+
+// readMode decodes a single mode descriptor from the cursor.
+func readMode(r *deviceReader) Mode {
+	m := Mode{}
+	m.Name = r.string()
+	m.Value = r.uint32()
+	m.Flags = r.uint32()
+	speedMin := r.uint32()
+	speedMax := r.uint32()
+	colorMin := r.uint32()
+	colorMax := r.uint32()
+	m.Speed = r.uint32()
+	m.Direction = r.uint32()
+	m.ColorMode = r.uint32()
+	m.SpeedMin = speedMin
+	m.SpeedMax = speedMax
+	m.ColorMin = colorMin
+	m.ColorMax = colorMax
+	m.Colors = r.colors()
+	return m
+}
+
+// noLEDMatrixEntry is the sentinel the OpenRGB SDK uses for a matrix position
+// with no backing LED.
+const noLEDMatrixEntry = 0xFFFFFFFF
+
+// readZone decodes a single zone descriptor from the cursor. For MATRIX-type
+// zones, MatrixLength is followed on the wire by a height, a width, and
+// height*width LED-index entries, which must be consumed here even though
+// they're only exposed on the Zone for matrix zones.
+func readZone(r *deviceReader) Zone {
+	z := Zone{}
+	z.Name = r.string()
+	z.Type = r.uint32()
+	z.LEDsMin = r.uint32()
+	z.LEDsMax = r.uint32()
+	z.LEDsCount = r.uint32()
+	z.MatrixLength = r.uint16()
+
+	if z.MatrixLength > 0 {
+		z.MatrixHeight = r.uint32()
+		z.MatrixWidth = r.uint32()
+		z.MatrixMap = make([]int, z.MatrixHeight*z.MatrixWidth)
+		for i := range z.MatrixMap {
+			entry := r.uint32()
+			if entry == noLEDMatrixEntry {
+				entry = -1
+			}
+			z.MatrixMap[i] = entry
+		}
+	}
+
+	return z
+}
+
+// encodeMode serializes a Mode into the payload layout expected by
+// NET_PACKET_ID_RGBCONTROLLER_UPDATEMODE and NET_PACKET_ID_RGBCONTROLLER_SAVEMODE,
+// the mirror of readMode. Like the sibling UpdateLEDs/UpdateZoneLEDs payloads,
+// the structure is self-sized: a 4-byte data_size field, redundant with the
+// frame header's own length, precedes the body it describes.
+func encodeMode(modeID int, mode Mode) []byte {
+	body := new(bytes.Buffer)
+	_ = binary.Write(body, binary.LittleEndian, uint32(modeID))
+	writeString(body, mode.Name)
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.Value))
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.Flags))
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.SpeedMin))
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.SpeedMax))
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.ColorMin))
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.ColorMax))
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.Speed))
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.Direction))
+	_ = binary.Write(body, binary.LittleEndian, uint32(mode.ColorMode))
+	writeColors(body, mode.Colors)
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(body.Len()))
+	buf.Write(body.Bytes())
+	return buf.Bytes()
+}
+
+// writeString encodes a string using the SDK's 2-byte-length-prefixed, NUL-terminated format.
+func writeString(buf *bytes.Buffer, s string) {
+	_ = binary.Write(buf, binary.LittleEndian, uint16(len(s)+1))
+	buf.WriteString(s)
+	buf.WriteByte(0)
+}
+
+// writeColors encodes a color list using the SDK's 2-byte-count-prefixed format.
+func writeColors(buf *bytes.Buffer, colors []Color) {
+	_ = binary.Write(buf, binary.LittleEndian, uint16(len(colors)))
+	for _, c := range colors {
+		buf.WriteByte(c.Red)
+		buf.WriteByte(c.Green)
+		buf.WriteByte(c.Blue)
+		buf.WriteByte(0)
+	}
+}
@@ -0,0 +1,53 @@
+package openrgb
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+)
+
+// TestEncodeModeHasDataSizePrefix confirms encodeMode follows the same
+// self-sized-structure convention as the LED/zone update payloads: a 4-byte
+// data_size field, describing the rest of the structure, precedes the body.
+func TestEncodeModeHasDataSizePrefix(t *testing.T) {
+	mode := Mode{Name: "Static", Value: 7, Colors: []Color{{Red: 1, Green: 2, Blue: 3}}}
+
+	payload := encodeMode(2, mode)
+	if len(payload) < 4 {
+		t.Fatalf("payload too short: %d bytes", len(payload))
+	}
+
+	size := binary.LittleEndian.Uint32(payload[:4])
+	body := payload[4:]
+	if int(size) != len(body) {
+		t.Fatalf("data_size prefix is %d, want len(body) = %d", size, len(body))
+	}
+
+	modeID := binary.LittleEndian.Uint32(body[:4])
+	if modeID != 2 {
+		t.Fatalf("first field of the body is %d, want modeID 2 (not clobbered by a missing size prefix)", modeID)
+	}
+}
+
+// TestClientGatesVerbsOnNegotiatedProtocolVersion confirms a verb introduced
+// in a later SDK protocol version fails clearly against an older server
+// instead of silently sending a command/format it doesn't support.
+func TestClientGatesVerbsOnNegotiatedProtocolVersion(t *testing.T) {
+	c := &Client{protocolVersion: 1}
+
+	if err := c.UpdateMode(0, 0, Mode{}); err == nil {
+		t.Fatal("expected UpdateMode to fail against a protocol version 1 server")
+	}
+	if err := c.SaveMode(0, 0, Mode{}); err == nil {
+		t.Fatal("expected SaveMode to fail against a protocol version 1 server")
+	}
+	if err := c.ResizeZone(0, 0, 10); err == nil {
+		t.Fatal("expected ResizeZone to fail against a protocol version 1 server")
+	}
+	if _, err := c.GetProfileListContext(context.Background()); err == nil {
+		t.Fatal("expected GetProfileList to fail against a protocol version 1 server")
+	}
+	if err := c.LoadProfile("x"); err == nil {
+		t.Fatal("expected LoadProfile to fail against a protocol version 1 server")
+	}
+}
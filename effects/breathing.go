@@ -0,0 +1,38 @@
+package effects
+
+import (
+	"math"
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// Breathing pulses every LED between off and Color and back, once per Period.
+type Breathing struct {
+	Color  openrgb.Color
+	Period time.Duration
+	FPS    int
+}
+
+// FrameRate is how often Breathing wants to be re-rendered.
+func (b Breathing) FrameRate() int { return b.FPS }
+
+// Frame returns Color scaled by a sine-wave brightness envelope.
+func (b Breathing) Frame(t time.Duration, ledCount int) []openrgb.Color {
+	period := b.Period
+	if period <= 0 {
+		period = 2 * time.Second
+	}
+
+	phase := float64(t%period) / float64(period)
+	brightness := (1 - math.Cos(2*math.Pi*phase)) / 2
+
+	off := openrgb.Color{}
+	c := blend(off, b.Color, brightness)
+
+	colors := make([]openrgb.Color, ledCount)
+	for i := range colors {
+		colors[i] = c
+	}
+	return colors
+}
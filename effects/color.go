@@ -0,0 +1,69 @@
+package effects
+
+import "github.com/dfuentes/openrgb-go"
+
+// blend linearly interpolates from dst to src by alpha, which is clamped to [0, 1].
+func blend(dst, src openrgb.Color, alpha float64) openrgb.Color {
+	if alpha <= 0 {
+		return dst
+	}
+	if alpha >= 1 {
+		return src
+	}
+
+	return openrgb.Color{
+		Red:   lerpChannel(dst.Red, src.Red, alpha),
+		Green: lerpChannel(dst.Green, src.Green, alpha),
+		Blue:  lerpChannel(dst.Blue, src.Blue, alpha),
+	}
+}
+
+func lerpChannel(from, to uint8, alpha float64) uint8 {
+	return uint8(float64(from) + (float64(to)-float64(from))*alpha)
+}
+
+// hsvToColor converts hue (degrees, any range, wrapped to [0, 360)), saturation
+// and value in [0, 1] to an openrgb.Color. Used by the Rainbow effect.
+func hsvToColor(hue, saturation, value float64) openrgb.Color {
+	hue = mod(hue, 360)
+	c := value * saturation
+	x := c * (1 - abs(mod(hue/60, 2)-1))
+	m := value - c
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = c, x, 0
+	case hue < 120:
+		r, g, b = x, c, 0
+	case hue < 180:
+		r, g, b = 0, c, x
+	case hue < 240:
+		r, g, b = 0, x, c
+	case hue < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return openrgb.Color{
+		Red:   uint8((r + m) * 255),
+		Green: uint8((g + m) * 255),
+		Blue:  uint8((b + m) * 255),
+	}
+}
+
+func mod(a, m float64) float64 {
+	r := a - float64(int(a/m))*m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,38 @@
+package effects
+
+import (
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// ColorWipe fills LEDs one at a time with Color, sweeping across the strip
+// once per Period and then starting over.
+type ColorWipe struct {
+	Color  openrgb.Color
+	Period time.Duration
+	FPS    int
+}
+
+// FrameRate is how often ColorWipe wants to be re-rendered.
+func (w ColorWipe) FrameRate() int { return w.FPS }
+
+// Frame lights LEDs [0, lit) with Color and leaves the rest off, where lit
+// grows linearly across Period before wrapping back to zero.
+func (w ColorWipe) Frame(t time.Duration, ledCount int) []openrgb.Color {
+	period := w.Period
+	if period <= 0 {
+		period = time.Second
+	}
+
+	phase := float64(t%period) / float64(period)
+	lit := int(phase * float64(ledCount))
+
+	colors := make([]openrgb.Color, ledCount)
+	for i := range colors {
+		if i < lit {
+			colors[i] = w.Color
+		}
+	}
+	return colors
+}
@@ -0,0 +1,44 @@
+package effects
+
+import (
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// Layer pairs an Effect with the alpha it's composited at: 0 is invisible,
+// 1 fully replaces whatever layers beneath it rendered.
+type Layer struct {
+	Effect Effect
+	Alpha  float64
+}
+
+// Compositor layers multiple effects on the same zone, blending each Layer
+// over the ones beneath it in order.
+type Compositor struct {
+	Layers []Layer
+	FPS    int
+}
+
+// FrameRate is how often the Compositor wants to be re-rendered.
+func (c Compositor) FrameRate() int { return c.FPS }
+
+// Frame renders every layer and blends them back-to-front using each layer's Alpha.
+func (c Compositor) Frame(t time.Duration, ledCount int) []openrgb.Color {
+	out := make([]openrgb.Color, ledCount)
+
+	for _, layer := range c.Layers {
+		if layer.Alpha <= 0 {
+			continue
+		}
+		frame := layer.Effect.Frame(t, ledCount)
+		for i := range out {
+			if i >= len(frame) {
+				continue
+			}
+			out[i] = blend(out[i], frame[i], layer.Alpha)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,58 @@
+// Package effects implements common LED lighting animations layered on top of
+// an *openrgb.Client. Each Effect produces colors as a pure function of
+// elapsed time, and Run (or a Scheduler, for multiple devices) drives that
+// function on a ticker, writing frames out via Client.UpdateLEDsContext.
+package effects
+
+import (
+	"context"
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// defaultFPS is used when an Effect doesn't implement FrameRater.
+const defaultFPS = 30
+
+// Effect produces one frame of colors for a given elapsed time and LED count.
+type Effect interface {
+	// Frame returns len(colors) == ledCount colors for time t since the
+	// effect started.
+	Frame(t time.Duration, ledCount int) []openrgb.Color
+}
+
+// FrameRater is implemented by effects that want to control how often they're
+// re-rendered. Effects that don't implement it run at defaultFPS.
+type FrameRater interface {
+	FrameRate() int
+}
+
+// effectiveFPS normalizes a zero-or-negative FPS field to defaultFPS.
+func effectiveFPS(fps int) int {
+	if fps <= 0 {
+		return defaultFPS
+	}
+	return fps
+}
+
+// fpsOf returns effect's requested frame rate, falling back to defaultFPS.
+func fpsOf(effect Effect) int {
+	if fr, ok := effect.(FrameRater); ok {
+		return effectiveFPS(fr.FrameRate())
+	}
+	return defaultFPS
+}
+
+// Run drives effect against deviceID by calling Client.UpdateLEDsContext on a
+// ticker, until ctx is done or a write fails.
+func Run(ctx context.Context, client *openrgb.Client, deviceID int, effect Effect) error {
+	device, err := client.GetDeviceControllerContext(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	s := NewScheduler(client, fpsOf(effect))
+	s.Add(deviceID, len(device.Colors), effect)
+
+	return s.Run(ctx)
+}
@@ -0,0 +1,96 @@
+package effects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// solidEffect is a fixed-color Effect, used where a test only cares about
+// blending/timeline behavior and not a specific Effect's own Frame logic.
+type solidEffect struct {
+	color openrgb.Color
+}
+
+func (s solidEffect) Frame(t time.Duration, ledCount int) []openrgb.Color {
+	colors := make([]openrgb.Color, ledCount)
+	for i := range colors {
+		colors[i] = s.color
+	}
+	return colors
+}
+
+func TestCompositorFrameBlendsLayersBackToFront(t *testing.T) {
+	red := openrgb.Color{Red: 255}
+	green := openrgb.Color{Green: 255}
+
+	c := Compositor{Layers: []Layer{
+		{Effect: solidEffect{color: red}, Alpha: 1},
+		{Effect: solidEffect{color: green}, Alpha: 0.5},
+	}}
+
+	got := c.Frame(0, 1)
+	if len(got) != 1 {
+		t.Fatalf("got %d colors, want 1", len(got))
+	}
+
+	want := blend(red, green, 0.5)
+	if got[0] != want {
+		t.Fatalf("got %+v, want %+v", got[0], want)
+	}
+}
+
+func TestCompositorFrameSkipsZeroAlphaLayers(t *testing.T) {
+	red := openrgb.Color{Red: 255}
+	green := openrgb.Color{Green: 255}
+
+	c := Compositor{Layers: []Layer{
+		{Effect: solidEffect{color: red}, Alpha: 1},
+		{Effect: solidEffect{color: green}, Alpha: 0},
+	}}
+
+	got := c.Frame(0, 1)
+	if got[0] != red {
+		t.Fatalf("got %+v, want untouched %+v", got[0], red)
+	}
+}
+
+// TestReactiveTriggerUsesFramesTimeline reproduces the scenario where a
+// Scheduler has been running for a while (so Frame's t is far from zero) and
+// Trigger is then called from the caller's wall clock: the LED must flash on
+// the very next Frame instead of being treated as already decayed.
+func TestReactiveTriggerUsesFramesTimeline(t *testing.T) {
+	r := &Reactive{
+		Base:   openrgb.Color{},
+		Active: openrgb.Color{Red: 255},
+		Decay:  time.Second,
+	}
+
+	// Establish the anchor as if the scheduler has already been running for
+	// 10s: this is the first Frame call, with t far from zero.
+	r.Frame(10*time.Second, 1)
+
+	r.Trigger(0)
+
+	got := r.Frame(10*time.Second+5*time.Millisecond, 1)
+	if got[0] == r.Base {
+		t.Fatalf("got Base immediately after Trigger, want it flashed toward Active %+v", r.Active)
+	}
+}
+
+func TestReactiveFrameDecaysToBase(t *testing.T) {
+	r := &Reactive{
+		Base:   openrgb.Color{},
+		Active: openrgb.Color{Red: 255},
+		Decay:  time.Second,
+	}
+
+	r.Frame(0, 1)
+	r.Trigger(0)
+
+	got := r.Frame(2*time.Second, 1)
+	if got[0] != r.Base {
+		t.Fatalf("got %+v after Decay has elapsed, want Base %+v", got[0], r.Base)
+	}
+}
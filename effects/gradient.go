@@ -0,0 +1,48 @@
+package effects
+
+import (
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// GradientScroll repeats Stops end-to-end across the strip and scrolls the
+// whole pattern along it, completing one full scroll per Period.
+type GradientScroll struct {
+	Stops  []openrgb.Color
+	Period time.Duration
+	FPS    int
+}
+
+// FrameRate is how often GradientScroll wants to be re-rendered.
+func (g GradientScroll) FrameRate() int { return g.FPS }
+
+// Frame samples the repeating gradient at each LED position, offset by how
+// far the pattern has scrolled since t=0.
+func (g GradientScroll) Frame(t time.Duration, ledCount int) []openrgb.Color {
+	colors := make([]openrgb.Color, ledCount)
+	if len(g.Stops) == 0 {
+		return colors
+	}
+	if len(g.Stops) == 1 {
+		for i := range colors {
+			colors[i] = g.Stops[0]
+		}
+		return colors
+	}
+
+	period := g.Period
+	if period <= 0 {
+		period = time.Second
+	}
+
+	scroll := float64(t%period) / float64(period) * float64(len(g.Stops))
+
+	for i := range colors {
+		pos := mod(float64(i)+scroll, float64(len(g.Stops)))
+		from := int(pos)
+		to := (from + 1) % len(g.Stops)
+		colors[i] = blend(g.Stops[from], g.Stops[to], pos-float64(from))
+	}
+	return colors
+}
@@ -0,0 +1,42 @@
+package effects
+
+import (
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// Rainbow sweeps a full hue cycle across the strip. Speed controls how many
+// full cycles pass per second; Speed 1.0 is one cycle per second.
+type Rainbow struct {
+	Speed float64
+	FPS   int
+}
+
+// FrameRate is how often Rainbow wants to be re-rendered.
+func (r Rainbow) FrameRate() int { return r.FPS }
+
+// Frame assigns each LED a hue offset around the wheel so the strip shows one
+// full rainbow at any instant, rotating over time at Speed cycles/second.
+func (r Rainbow) Frame(t time.Duration, ledCount int) []openrgb.Color {
+	speed := r.Speed
+	if speed == 0 {
+		speed = 1
+	}
+
+	base := 360 * speed * t.Seconds()
+
+	colors := make([]openrgb.Color, ledCount)
+	for i := range colors {
+		hue := base + float64(i)*360/float64(max(ledCount, 1))
+		colors[i] = hsvToColor(hue, 1, 1)
+	}
+	return colors
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,90 @@
+package effects
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// Reactive holds every LED at Base and flashes Active on a LED when Trigger
+// is called for it, decaying back to Base over Decay. Callers wire Trigger up
+// to whatever external event (a keypress, an audio peak, ...) should light
+// the strip up.
+type Reactive struct {
+	Base   openrgb.Color
+	Active openrgb.Color
+	Decay  time.Duration
+	FPS    int
+
+	mu        sync.Mutex
+	anchorAt  time.Time
+	anchorT   time.Duration
+	triggered map[int]time.Duration
+}
+
+// FrameRate is how often Reactive wants to be re-rendered.
+func (r *Reactive) FrameRate() int { return r.FPS }
+
+// Trigger flashes Active on ledIndex, starting its decay back to Base.
+//
+// Trigger fires from the caller's wall clock (a keypress, an audio peak),
+// while Frame is driven with t measured from whatever start the Scheduler
+// chose, so Trigger converts its wall-clock instant to that same timeline via
+// the anchor Frame establishes on its first call.
+func (r *Reactive) Trigger(ledIndex int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.triggered == nil {
+		r.triggered = make(map[int]time.Duration)
+	}
+	r.triggered[ledIndex] = r.frameTimeLocked(time.Now())
+}
+
+// frameTimeLocked converts the wall-clock instant at into the same timeline
+// as the t values Frame is called with, anchored to Frame's first call.
+// Called before that anchor exists (a Trigger preceding any Frame), it falls
+// back to t=0, which is a reasonable approximation since a Scheduler's first
+// Frame call follows shortly after it starts.
+func (r *Reactive) frameTimeLocked(at time.Time) time.Duration {
+	if r.anchorAt.IsZero() {
+		return 0
+	}
+	return r.anchorT + at.Sub(r.anchorAt)
+}
+
+// Frame returns Base for every LED except those recently Triggered, which
+// fade linearly from Active back to Base over Decay.
+func (r *Reactive) Frame(t time.Duration, ledCount int) []openrgb.Color {
+	decay := r.Decay
+	if decay <= 0 {
+		decay = time.Second
+	}
+
+	colors := make([]openrgb.Color, ledCount)
+	for i := range colors {
+		colors[i] = r.Base
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.anchorAt.IsZero() {
+		r.anchorAt = time.Now()
+		r.anchorT = t
+	}
+
+	for i, triggeredAt := range r.triggered {
+		if i < 0 || i >= ledCount {
+			continue
+		}
+		elapsed := t - triggeredAt
+		if elapsed < 0 || elapsed >= decay {
+			continue
+		}
+		colors[i] = blend(r.Active, r.Base, float64(elapsed)/float64(decay))
+	}
+
+	return colors
+}
@@ -0,0 +1,69 @@
+package effects
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// target is one device driven by a Scheduler.
+type target struct {
+	deviceID int
+	ledCount int
+	effect   Effect
+}
+
+// Scheduler drives effects for multiple devices off a single shared ticker,
+// writing every device's frame back-to-back within the same tick. That keeps
+// multi-device setups in sync at high FPS, where a naive per-device sleep loop
+// drifts: each UpdateLEDs call round-trips a TCP write, so sleeping relative to
+// "now" after each write accumulates error across devices.
+type Scheduler struct {
+	client *openrgb.Client
+	fps    int
+
+	mu      sync.Mutex
+	targets []target
+}
+
+// NewScheduler creates a Scheduler that ticks at fps (clamped to defaultFPS
+// when fps <= 0), writing frames to client.
+func NewScheduler(client *openrgb.Client, fps int) *Scheduler {
+	return &Scheduler{client: client, fps: effectiveFPS(fps)}
+}
+
+// Add registers effect to run against deviceID, which has ledCount LEDs.
+func (s *Scheduler) Add(deviceID, ledCount int, effect Effect) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.targets = append(s.targets, target{deviceID: deviceID, ledCount: ledCount, effect: effect})
+}
+
+// Run drives every registered target until ctx is done or a write fails.
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(time.Second / time.Duration(s.fps))
+	defer ticker.Stop()
+
+	start := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case tick := <-ticker.C:
+			elapsed := tick.Sub(start)
+
+			s.mu.Lock()
+			targets := append([]target(nil), s.targets...)
+			s.mu.Unlock()
+
+			for _, tg := range targets {
+				colors := tg.effect.Frame(elapsed, tg.ledCount)
+				if err := s.client.UpdateLEDsContext(ctx, tg.deviceID, colors); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package effects
+
+import (
+	"time"
+
+	"github.com/dfuentes/openrgb-go"
+)
+
+// Solid fills every LED with a single, unchanging color.
+type Solid struct {
+	Color openrgb.Color
+	FPS   int
+}
+
+// FrameRate is how often Solid wants to be re-rendered.
+func (s Solid) FrameRate() int { return s.FPS }
+
+// Frame returns Color for every LED, regardless of t.
+func (s Solid) Frame(t time.Duration, ledCount int) []openrgb.Color {
+	colors := make([]openrgb.Color, ledCount)
+	for i := range colors {
+		colors[i] = s.Color
+	}
+	return colors
+}
@@ -0,0 +1,75 @@
+package openrgb
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Network packet IDs as defined by the OpenRGB SDK protocol.
+const (
+	commandRequestControllerCount = 0
+	commandRequestControllerData  = 1
+	commandRequestProtocolVersion = 40
+	commandSetClientName          = 50
+	commandDeviceListUpdated      = 100
+	commandRequestProfileList     = 150
+	commandRequestSaveProfile     = 151
+	commandRequestLoadProfile     = 152
+	commandRequestDeleteProfile   = 153
+	commandResizeZone             = 1000
+	commandUpdateLEDs             = 1050
+	commandUpdateZoneLEDs         = 1051
+	commandUpdateSingleLED        = 1052
+	commandSetCustomMode          = 1100
+	commandUpdateMode             = 1101
+	commandSaveMode               = 1102
+)
+
+// offset32LEBits is the byte offset of the 2-byte LED count field that
+// follows the 4-byte zone ID in zone-scoped update payloads.
+const offset32LEBits = 4
+
+// currentProtocolVersion is the highest SDK protocol version this client understands.
+// It is sent during negotiation and clamped down to whatever the server supports.
+const currentProtocolVersion = 4
+
+// minProtocolVersion maps a verb's command to the earliest negotiated SDK
+// protocol version required to use it, matching when each was added upstream:
+// profiles and mode persistence in version 2, zone resizing in version 3.
+// A command absent from this map is assumed supported since version 1.
+var minProtocolVersion = map[int]int{
+	commandUpdateMode:           2,
+	commandSaveMode:             2,
+	commandRequestProfileList:   2,
+	commandRequestSaveProfile:   2,
+	commandRequestLoadProfile:   2,
+	commandRequestDeleteProfile: 2,
+	commandResizeZone:           3,
+}
+
+const headerMagic = "ORGB"
+
+// orgbHeader is the 16-byte frame header prefixed to every OpenRGB SDK packet.
+type orgbHeader struct {
+	deviceID  uint32
+	commandID uint32
+	length    uint32
+}
+
+// encodeHeader serializes an orgbHeader into its 16-byte wire representation.
+func encodeHeader(h orgbHeader) *bytes.Buffer {
+	buf := bytes.NewBufferString(headerMagic)
+	_ = binary.Write(buf, binary.LittleEndian, h.deviceID)
+	_ = binary.Write(buf, binary.LittleEndian, h.commandID)
+	_ = binary.Write(buf, binary.LittleEndian, h.length)
+	return buf
+}
+
+// decodeHeader parses a 16-byte frame header. Callers must pass exactly 16 bytes.
+func decodeHeader(buf []byte) orgbHeader {
+	return orgbHeader{
+		deviceID:  binary.LittleEndian.Uint32(buf[4:8]),
+		commandID: binary.LittleEndian.Uint32(buf[8:12]),
+		length:    binary.LittleEndian.Uint32(buf[12:16]),
+	}
+}
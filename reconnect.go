@@ -0,0 +1,450 @@
+package openrgb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+// ConnState describes a ReconnectingClient's current relationship to the server.
+type ConnState int
+
+const (
+	// Connected means the underlying Client is usable.
+	Connected ConnState = iota
+	// Reconnecting means the connection was lost and a redial is in progress.
+	Reconnecting
+	// Closed means Close was called or MaxAttempts redials failed; the
+	// ReconnectingClient will not redial again.
+	Closed
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case Connected:
+		return "connected"
+	case Reconnecting:
+		return "reconnecting"
+	case Closed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// ReconnectBackoff configures the delay between a ReconnectingClient's redial attempts.
+type ReconnectBackoff struct {
+	// InitialDelay is the delay before the first retry. Defaults to 500ms.
+	InitialDelay time.Duration
+	// MaxDelay caps the exponential delay between retries, before Jitter is
+	// applied; a jittered delay can exceed it by up to Jitter*MaxDelay.
+	// Defaults to 30s.
+	MaxDelay time.Duration
+	// Jitter is the fraction, in [0, 1], of each delay to randomize by.
+	Jitter float64
+	// MaxAttempts caps how many redials are attempted before giving up and
+	// transitioning to Closed. Zero means retry forever.
+	MaxAttempts int
+}
+
+// delay returns the backoff delay before retry number attempt (0-indexed).
+func (b ReconnectBackoff) delay(attempt int) time.Duration {
+	initial := b.InitialDelay
+	if initial <= 0 {
+		initial = 500 * time.Millisecond
+	}
+	maxDelay := b.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+
+	shift := attempt
+	if shift > 32 {
+		shift = 32
+	}
+	d := initial * time.Duration(uint64(1)<<uint(shift))
+	if d <= 0 || d > maxDelay {
+		d = maxDelay
+	}
+
+	if b.Jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * b.Jitter * float64(d))
+		if d < 0 {
+			d = 0
+		}
+	}
+
+	return d
+}
+
+// ReconnectingClient wraps a Client and transparently redials the OpenRGB
+// server, with exponential backoff, whenever a send or read fails with a
+// network error. Re-dialing re-sends SetClientName, re-negotiates the SDK
+// protocol version, and re-registers every OnDeviceListUpdated subscriber.
+type ReconnectingClient struct {
+	host    string
+	port    int
+	opts    []ClientOption
+	backoff ReconnectBackoff
+
+	mu        sync.RWMutex
+	client    *Client
+	state     ConnState
+	nextSubID int
+	subs      map[int]*deviceListSub
+
+	notifyMu sync.Mutex
+	notify   []chan<- ConnState
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// DialReconnecting connects to host:port and wraps the result in a
+// ReconnectingClient that redials automatically using backoff.
+func DialReconnecting(host string, port int, backoff ReconnectBackoff, opts ...ClientOption) (*ReconnectingClient, error) {
+	rc := &ReconnectingClient{
+		host:    host,
+		port:    port,
+		opts:    opts,
+		backoff: backoff,
+		subs:    make(map[int]*deviceListSub),
+		closed:  make(chan struct{}),
+	}
+
+	client, err := ConnectWithOptions(host, port, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.client = client
+	rc.state = Connected
+	rc.watch(client)
+
+	return rc, nil
+}
+
+// State returns the ReconnectingClient's current connection state.
+func (rc *ReconnectingClient) State() ConnState {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.state
+}
+
+// Notify registers ch to receive every subsequent ConnState transition. Sends
+// are non-blocking; a slow receiver misses states rather than stalling the
+// reconnect loop.
+func (rc *ReconnectingClient) Notify(ch chan<- ConnState) {
+	rc.notifyMu.Lock()
+	defer rc.notifyMu.Unlock()
+	rc.notify = append(rc.notify, ch)
+}
+
+// Close stops any in-progress reconnection and closes the underlying Client.
+func (rc *ReconnectingClient) Close() error {
+	rc.closeOnce.Do(func() { close(rc.closed) })
+
+	rc.mu.Lock()
+	rc.state = Closed
+	client := rc.client
+	rc.mu.Unlock()
+
+	rc.setState(Closed)
+
+	if client != nil {
+		return client.Close()
+	}
+	return nil
+}
+
+// deviceListSub tracks one OnDeviceListUpdated registration: the callback
+// itself, re-registered against every Client a reconnect produces, and the
+// inner Client's cancel for whichever Client it's currently registered on.
+type deviceListSub struct {
+	fn     func()
+	cancel func()
+}
+
+// OnDeviceListUpdated registers fn against the current underlying Client and
+// remembers it so it's re-registered against every Client produced by a
+// future reconnect. The returned cancel func unregisters fn from both the
+// currently-live Client and any future reconnect.
+func (rc *ReconnectingClient) OnDeviceListUpdated(fn func()) (cancel func()) {
+	rc.mu.Lock()
+	id := rc.nextSubID
+	rc.nextSubID++
+	sub := &deviceListSub{fn: fn}
+	rc.subs[id] = sub
+	client := rc.client
+	rc.mu.Unlock()
+
+	if client != nil {
+		inner := client.OnDeviceListUpdated(fn)
+		rc.mu.Lock()
+		sub.cancel = inner
+		rc.mu.Unlock()
+	}
+
+	return func() {
+		rc.mu.Lock()
+		s, ok := rc.subs[id]
+		delete(rc.subs, id)
+		rc.mu.Unlock()
+
+		if ok && s.cancel != nil {
+			s.cancel()
+		}
+	}
+}
+
+func (rc *ReconnectingClient) setState(s ConnState) {
+	rc.notifyMu.Lock()
+	chans := append([]chan<- ConnState(nil), rc.notify...)
+	rc.notifyMu.Unlock()
+
+	for _, ch := range chans {
+		select {
+		case ch <- s:
+		default:
+		}
+	}
+}
+
+// watch waits for client to disconnect and, unless Close was called first,
+// kicks off the reconnect loop.
+func (rc *ReconnectingClient) watch(client *Client) {
+	go func() {
+		<-client.closed
+		rc.reconnect()
+	}()
+}
+
+func (rc *ReconnectingClient) reconnect() {
+	rc.mu.Lock()
+	if rc.state == Closed {
+		rc.mu.Unlock()
+		return
+	}
+	rc.state = Reconnecting
+	rc.mu.Unlock()
+	rc.setState(Reconnecting)
+
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-rc.closed:
+			return
+		default:
+		}
+
+		if rc.backoff.MaxAttempts > 0 && attempt >= rc.backoff.MaxAttempts {
+			rc.mu.Lock()
+			rc.state = Closed
+			rc.mu.Unlock()
+			rc.setState(Closed)
+			return
+		}
+
+		select {
+		case <-time.After(rc.backoff.delay(attempt)):
+		case <-rc.closed:
+			return
+		}
+
+		client, err := ConnectWithOptions(rc.host, rc.port, rc.opts...)
+		if err != nil {
+			continue
+		}
+
+		rc.mu.Lock()
+		if rc.state == Closed {
+			rc.mu.Unlock()
+			client.Close()
+			return
+		}
+		rc.client = client
+		subs := make([]*deviceListSub, 0, len(rc.subs))
+		for _, s := range rc.subs {
+			subs = append(subs, s)
+		}
+		rc.mu.Unlock()
+
+		// Re-register every subscriber before reporting Connected, so a caller
+		// that reacts to the state transition can rely on its subscriptions
+		// already being live on the new Client.
+		for _, s := range subs {
+			inner := client.OnDeviceListUpdated(s.fn)
+			rc.mu.Lock()
+			s.cancel = inner
+			rc.mu.Unlock()
+		}
+
+		rc.mu.Lock()
+		if rc.state == Closed {
+			// Close ran during re-registration and already closed client.
+			rc.mu.Unlock()
+			return
+		}
+		rc.state = Connected
+		rc.mu.Unlock()
+
+		rc.setState(Connected)
+		rc.watch(client)
+		return
+	}
+}
+
+// current returns the Client currently in use, or an error if closed.
+func (rc *ReconnectingClient) current() (*Client, error) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	if rc.state == Closed {
+		return nil, net.ErrClosed
+	}
+	return rc.client, nil
+}
+
+// call runs fn against the current Client and, if fn's error looks like a
+// dropped connection, closes that Client so its background reader's exit
+// kicks off a reconnect.
+func (rc *ReconnectingClient) call(fn func(*Client) error) error {
+	client, err := rc.current()
+	if err != nil {
+		return err
+	}
+
+	err = fn(client)
+	if isConnError(err) {
+		client.Close()
+	}
+	return err
+}
+
+func isConnError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) || errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed)
+}
+
+// GetControllerCount is Client.GetControllerCount, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) GetControllerCount() (count int, err error) {
+	err = rc.call(func(c *Client) error {
+		count, err = c.GetControllerCount()
+		return err
+	})
+	return
+}
+
+// GetControllerCountContext is GetControllerCount with caller-supplied cancellation.
+func (rc *ReconnectingClient) GetControllerCountContext(ctx context.Context) (count int, err error) {
+	err = rc.call(func(c *Client) error {
+		count, err = c.GetControllerCountContext(ctx)
+		return err
+	})
+	return
+}
+
+// GetDeviceController is Client.GetDeviceController, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) GetDeviceController(deviceID int) (device Device, err error) {
+	err = rc.call(func(c *Client) error {
+		device, err = c.GetDeviceController(deviceID)
+		return err
+	})
+	return
+}
+
+// GetDeviceControllerContext is GetDeviceController with caller-supplied cancellation.
+func (rc *ReconnectingClient) GetDeviceControllerContext(ctx context.Context, deviceID int) (device Device, err error) {
+	err = rc.call(func(c *Client) error {
+		device, err = c.GetDeviceControllerContext(ctx, deviceID)
+		return err
+	})
+	return
+}
+
+// UpdateLEDs is Client.UpdateLEDs, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) UpdateLEDs(deviceID int, colors []Color) error {
+	return rc.call(func(c *Client) error { return c.UpdateLEDs(deviceID, colors) })
+}
+
+// UpdateZoneLEDs is Client.UpdateZoneLEDs, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) UpdateZoneLEDs(deviceID, zoneID int, colors []Color) error {
+	return rc.call(func(c *Client) error { return c.UpdateZoneLEDs(deviceID, zoneID, colors) })
+}
+
+// UpdateSingleLED is Client.UpdateSingleLED, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) UpdateSingleLED(deviceID, ledID int, color Color) error {
+	return rc.call(func(c *Client) error { return c.UpdateSingleLED(deviceID, ledID, color) })
+}
+
+// SetCustomMode is Client.SetCustomMode, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) SetCustomMode(deviceID int) error {
+	return rc.call(func(c *Client) error { return c.SetCustomMode(deviceID) })
+}
+
+// UpdateMode is Client.UpdateMode, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) UpdateMode(deviceID, modeID int, mode Mode) error {
+	return rc.call(func(c *Client) error { return c.UpdateMode(deviceID, modeID, mode) })
+}
+
+// SaveMode is Client.SaveMode, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) SaveMode(deviceID, modeID int, mode Mode) error {
+	return rc.call(func(c *Client) error { return c.SaveMode(deviceID, modeID, mode) })
+}
+
+// ResizeZone is Client.ResizeZone, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) ResizeZone(deviceID, zoneID, newSize int) error {
+	return rc.call(func(c *Client) error { return c.ResizeZone(deviceID, zoneID, newSize) })
+}
+
+// GetProfileList is Client.GetProfileList, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) GetProfileList() (names []string, err error) {
+	err = rc.call(func(c *Client) error {
+		names, err = c.GetProfileList()
+		return err
+	})
+	return
+}
+
+// GetProfileListContext is GetProfileList with caller-supplied cancellation.
+func (rc *ReconnectingClient) GetProfileListContext(ctx context.Context) (names []string, err error) {
+	err = rc.call(func(c *Client) error {
+		names, err = c.GetProfileListContext(ctx)
+		return err
+	})
+	return
+}
+
+// LoadProfile is Client.LoadProfile, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) LoadProfile(name string) error {
+	return rc.call(func(c *Client) error { return c.LoadProfile(name) })
+}
+
+// SaveProfile is Client.SaveProfile, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) SaveProfile(name string) error {
+	return rc.call(func(c *Client) error { return c.SaveProfile(name) })
+}
+
+// DeleteProfile is Client.DeleteProfile, transparently reconnecting on a dropped connection.
+func (rc *ReconnectingClient) DeleteProfile(name string) error {
+	return rc.call(func(c *Client) error { return c.DeleteProfile(name) })
+}
+
+// Client returns the Client currently in use, or an error if ReconnectingClient
+// is Closed. The returned pointer can be swapped out from under the caller by
+// a reconnect; prefer ReconnectingClient's own methods, which always act on
+// whichever Client is current, for anything long-lived.
+func (rc *ReconnectingClient) Client() (*Client, error) {
+	return rc.current()
+}
+
+// WatchDevices is Client.WatchDevices, transparently reconnecting on a dropped
+// connection and re-subscribing to device-list updates after each reconnect.
+func (rc *ReconnectingClient) WatchDevices(ctx context.Context) <-chan []Device {
+	return watchDevices(ctx, rc)
+}
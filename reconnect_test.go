@@ -0,0 +1,113 @@
+package openrgb
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// serveHandshake plays just enough of the server side of the OpenRGB SDK
+// protocol for a Client's Connect/ConnectWithOptions to succeed: drain
+// SetClientName (fire-and-forget) and answer the protocol version request.
+func serveHandshake(conn net.Conn) error {
+	if _, _, err := readRawFrame(conn); err != nil {
+		return err
+	}
+	if _, _, err := readRawFrame(conn); err != nil {
+		return err
+	}
+	return writeRawFrame(conn, uint32(commandRequestProtocolVersion), 0, le32(currentProtocolVersion))
+}
+
+func waitForState(t *testing.T, rc *ReconnectingClient, want ConnState, timeout time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if rc.State() == want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for state %v, last observed %v", want, rc.State())
+}
+
+// TestReconnectingClientReconnectsAndResubscribes simulates a server restart
+// by dropping the TCP connection, then checks that ReconnectingClient redials,
+// and that an OnDeviceListUpdated subscriber registered before the drop still
+// fires once it's re-registered against the new connection.
+func TestReconnectingClientReconnectsAndResubscribes(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	conns := make(chan net.Conn, 4)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				if serveHandshake(conn) == nil {
+					conns <- conn
+				}
+			}()
+		}
+	}()
+
+	host, portStr, err := net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("split addr: %v", err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("parse port: %v", err)
+	}
+
+	rc, err := DialReconnecting(host, port, ReconnectBackoff{InitialDelay: 10 * time.Millisecond, MaxDelay: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("DialReconnecting: %v", err)
+	}
+	defer rc.Close()
+
+	var firstConn net.Conn
+	select {
+	case firstConn = <-conns:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server never saw the initial connection")
+	}
+
+	updates := make(chan struct{}, 4)
+	rc.OnDeviceListUpdated(func() { updates <- struct{}{} })
+
+	firstConn.Close()
+
+	var secondConn net.Conn
+	select {
+	case secondConn = <-conns:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server never saw the reconnect")
+	}
+	defer secondConn.Close()
+
+	// ReconnectingClient only reports Connected once every subscriber has been
+	// re-registered against the new Client, so waiting for it here (rather
+	// than before grabbing secondConn, when the state could still be the
+	// stale Connected from before the drop) guarantees the resubscribe below
+	// has already happened.
+	waitForState(t, rc, Connected, 2*time.Second)
+
+	if err := writeRawFrame(secondConn, uint32(commandDeviceListUpdated), 0, nil); err != nil {
+		t.Fatalf("write device-list-updated: %v", err)
+	}
+
+	select {
+	case <-updates:
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscriber registered before the reconnect was never re-registered")
+	}
+}
@@ -0,0 +1,148 @@
+package openrgb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// deviceListDebounce is how long WatchDevices waits after a device-list-updated
+// event before re-fetching, coalescing bursts of events (e.g. during enumeration
+// at server startup) into a single refresh.
+const deviceListDebounce = 50 * time.Millisecond
+
+// OnDeviceListUpdated registers fn to be invoked whenever the OpenRGB server
+// reports that its device list changed (a controller was added or removed).
+// Each registered fn runs in its own goroutine so a slow handler can't block
+// the reader or delay other subscribers. The returned cancel func unregisters
+// fn; it is safe to call more than once.
+func (c *Client) OnDeviceListUpdated(fn func()) (cancel func()) {
+	c.subMu.Lock()
+	id := c.nextSubID
+	c.nextSubID++
+	c.deviceListSubscribers[id] = fn
+	c.subMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.subMu.Lock()
+			delete(c.deviceListSubscribers, id)
+			c.subMu.Unlock()
+		})
+	}
+}
+
+// dispatchDeviceListUpdated fans a device-list-updated event out to every
+// subscriber, each in its own goroutine.
+func (c *Client) dispatchDeviceListUpdated() {
+	c.subMu.Lock()
+	fns := make([]func(), 0, len(c.deviceListSubscribers))
+	for _, fn := range c.deviceListSubscribers {
+		fns = append(fns, fn)
+	}
+	c.subMu.Unlock()
+
+	for _, fn := range fns {
+		go fn()
+	}
+}
+
+// WatchDevices returns a channel that receives the current controller list
+// whenever the server reports a device-list change, debounced so that a burst
+// of events arriving within deviceListDebounce collapses into one refresh. The
+// channel is closed, and the subscription torn down, once ctx is done.
+func (c *Client) WatchDevices(ctx context.Context) <-chan []Device {
+	return watchDevices(ctx, c)
+}
+
+// deviceWatcher is whatever WatchDevices needs: a way to hear about
+// device-list changes and a way to re-fetch the controller list. Both Client
+// and ReconnectingClient implement it, so watchDevices backs both of their
+// WatchDevices methods with one implementation.
+type deviceWatcher interface {
+	OnDeviceListUpdated(fn func()) (cancel func())
+	GetControllerCountContext(ctx context.Context) (int, error)
+	GetDeviceControllerContext(ctx context.Context, deviceID int) (Device, error)
+}
+
+// watchDevices is the shared implementation behind Client.WatchDevices and
+// ReconnectingClient.WatchDevices.
+func watchDevices(ctx context.Context, w deviceWatcher) <-chan []Device {
+	out := make(chan []Device)
+	events := make(chan struct{}, 1)
+
+	cancel := w.OnDeviceListUpdated(func() {
+		select {
+		case events <- struct{}{}:
+		default:
+		}
+	})
+
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		refresh := func() {
+			devices, err := fetchDevices(ctx, w)
+			if err != nil {
+				return
+			}
+			select {
+			case out <- devices:
+			case <-ctx.Done():
+			}
+		}
+
+		refresh()
+
+		var debounce *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				if debounce != nil {
+					debounce.Stop()
+				}
+				return
+			case <-events:
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.NewTimer(deviceListDebounce)
+			case <-timerC(debounce):
+				debounce = nil
+				refresh()
+			}
+		}
+	}()
+
+	return out
+}
+
+// timerC returns t's channel, or nil if t is nil. A nil channel blocks forever
+// in a select, which is exactly what's needed while no debounce is pending.
+func timerC(t *time.Timer) <-chan time.Time {
+	if t == nil {
+		return nil
+	}
+	return t.C
+}
+
+// fetchDevices re-reads the full controller list through w.
+func fetchDevices(ctx context.Context, w deviceWatcher) ([]Device, error) {
+	count, err := w.GetControllerCountContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]Device, count)
+	for i := 0; i < count; i++ {
+		d, err := w.GetDeviceControllerContext(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		devices[i] = d
+	}
+
+	return devices, nil
+}
@@ -0,0 +1,119 @@
+package openrgb
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestClientOnDeviceListUpdatedFanOut confirms every registered subscriber is
+// invoked on a device-list-updated event, and that canceling one subscriber
+// doesn't affect the others or future events.
+func TestClientOnDeviceListUpdatedFanOut(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	c := newClient(clientConn, clientConfig{})
+	defer c.Close()
+
+	gotA := make(chan struct{}, 4)
+	gotB := make(chan struct{}, 4)
+	cancelA := c.OnDeviceListUpdated(func() { gotA <- struct{}{} })
+	c.OnDeviceListUpdated(func() { gotB <- struct{}{} })
+
+	go func() { _ = writeRawFrame(serverConn, uint32(commandDeviceListUpdated), 0, nil) }()
+
+	timeout := time.After(2 * time.Second)
+	select {
+	case <-gotA:
+	case <-timeout:
+		t.Fatal("subscriber A was never invoked")
+	}
+	select {
+	case <-gotB:
+	case <-timeout:
+		t.Fatal("subscriber B was never invoked")
+	}
+
+	cancelA()
+
+	go func() { _ = writeRawFrame(serverConn, uint32(commandDeviceListUpdated), 0, nil) }()
+
+	select {
+	case <-gotB:
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscriber B was never invoked for the second event")
+	}
+
+	select {
+	case <-gotA:
+		t.Fatal("canceled subscriber A was invoked after being unregistered")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// serveZeroDevices answers every GetControllerCount request with 0, so
+// WatchDevices's refresh only has to round-trip that one request.
+func serveZeroDevices(t *testing.T, conn net.Conn) {
+	t.Helper()
+	go func() {
+		for {
+			header, _, err := readRawFrame(conn)
+			if err != nil {
+				return
+			}
+			if int(header.commandID) == commandRequestControllerCount {
+				if err := writeRawFrame(conn, header.commandID, header.deviceID, le32(0)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// TestClientWatchDevicesDebouncesBurstsOfEvents confirms a burst of
+// device-list-updated events arriving within deviceListDebounce collapses
+// into a single refresh, rather than one refresh per event.
+func TestClientWatchDevicesDebouncesBurstsOfEvents(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	c := newClient(clientConn, clientConfig{})
+	defer c.Close()
+
+	serveZeroDevices(t, serverConn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := c.WatchDevices(ctx)
+
+	select {
+	case <-out:
+	case <-time.After(2 * time.Second):
+		t.Fatal("never received the initial refresh")
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := writeRawFrame(serverConn, uint32(commandDeviceListUpdated), 0, nil); err != nil {
+			t.Fatalf("write device-list-updated: %v", err)
+		}
+	}
+
+	refreshes := 0
+	deadline := time.After(deviceListDebounce + 500*time.Millisecond)
+loop:
+	for {
+		select {
+		case <-out:
+			refreshes++
+		case <-deadline:
+			break loop
+		}
+	}
+
+	if refreshes != 1 {
+		t.Fatalf("got %d refreshes after a burst of 5 events, want exactly 1 (the debounce should have coalesced them)", refreshes)
+	}
+}
@@ -0,0 +1,42 @@
+package openrgb
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+)
+
+// readRawFrame reads one OpenRGB SDK frame directly off conn, bypassing Client.
+// Tests use it to play the server side of the protocol.
+func readRawFrame(conn net.Conn) (orgbHeader, []byte, error) {
+	buf := make([]byte, 16)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return orgbHeader{}, nil, err
+	}
+
+	header := decodeHeader(buf)
+
+	payload := make([]byte, header.length)
+	if header.length > 0 {
+		if _, err := io.ReadFull(conn, payload); err != nil {
+			return orgbHeader{}, nil, err
+		}
+	}
+
+	return header, payload, nil
+}
+
+// writeRawFrame writes one OpenRGB SDK frame directly to conn, bypassing Client.
+func writeRawFrame(conn net.Conn, commandID, deviceID uint32, payload []byte) error {
+	h := encodeHeader(orgbHeader{deviceID: deviceID, commandID: commandID, length: uint32(len(payload))})
+	h.Write(payload)
+	_, err := conn.Write(h.Bytes())
+	return err
+}
+
+// le32 little-endian-encodes v, for building raw frame payloads in tests.
+func le32(v uint32) []byte {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, v)
+	return buf
+}